@@ -0,0 +1,78 @@
+package rss
+
+import (
+	"encoding/xml"
+	"html"
+)
+
+type atomDocument struct {
+	Title   string      `xml:"title"`
+	Link    []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+type atomEntry struct {
+	Title     string     `xml:"title"`
+	Link      []atomLink `xml:"link"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+	ID        string     `xml:"id"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Author    atomAuthor `xml:"author"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+func parseAtom(body []byte) (*Feed, error) {
+	var doc atomDocument
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+
+	feed := &Feed{
+		Title: html.UnescapeString(doc.Title),
+		Link:  atomLinkHref(doc.Link),
+	}
+
+	for _, entry := range doc.Entries {
+		published := entry.Published
+		if published == "" {
+			published = entry.Updated
+		}
+
+		item := Item{
+			Title:       html.UnescapeString(entry.Title),
+			Link:        atomLinkHref(entry.Link),
+			Description: html.UnescapeString(entry.Summary),
+			Content:     entry.Content,
+			GUID:        entry.ID,
+			Published:   parseTime(published),
+		}
+		if entry.Author.Name != "" {
+			item.Authors = []string{entry.Author.Name}
+		}
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed, nil
+}
+
+func atomLinkHref(links []atomLink) string {
+	for _, link := range links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return link.Href
+		}
+	}
+	if len(links) > 0 {
+		return links[0].Href
+	}
+	return ""
+}
@@ -0,0 +1,24 @@
+package rss
+
+import "time"
+
+// Feed is a format-agnostic representation of an RSS 2.0, Atom 1.0, or
+// JSON Feed 1.1 document.
+type Feed struct {
+	Title       string
+	Link        string
+	Description string
+	Items       []Item
+}
+
+// Item is a single entry within a Feed, normalized across the supported
+// feed formats.
+type Item struct {
+	Title       string
+	Link        string
+	Description string
+	Content     string
+	Published   time.Time
+	GUID        string
+	Authors     []string
+}
@@ -0,0 +1,30 @@
+package rss
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// Fetch downloads feedURL and parses it as RSS, Atom, or JSON Feed,
+// whichever the response turns out to be.
+func Fetch(ctx context.Context, feedURL string) (*Feed, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("User-Agent", "gator")
+
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(resp.Header.Get("Content-Type"), body)
+}
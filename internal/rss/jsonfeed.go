@@ -0,0 +1,58 @@
+package rss
+
+import "encoding/json"
+
+type jsonFeedDocument struct {
+	Title string         `json:"title"`
+	Home  string         `json:"home_page_url"`
+	Items []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	Summary       string           `json:"summary"`
+	ContentText   string           `json:"content_text"`
+	ContentHTML   string           `json:"content_html"`
+	DatePublished string           `json:"date_published"`
+	Authors       []jsonFeedAuthor `json:"authors"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+func parseJSONFeed(body []byte) (*Feed, error) {
+	var doc jsonFeedDocument
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+
+	feed := &Feed{
+		Title: doc.Title,
+		Link:  doc.Home,
+	}
+
+	for _, docItem := range doc.Items {
+		content := docItem.ContentHTML
+		if content == "" {
+			content = docItem.ContentText
+		}
+
+		item := Item{
+			Title:       docItem.Title,
+			Link:        docItem.URL,
+			Description: docItem.Summary,
+			Content:     content,
+			GUID:        docItem.ID,
+			Published:   parseTime(docItem.DatePublished),
+		}
+		for _, author := range docItem.Authors {
+			item.Authors = append(item.Authors, author.Name)
+		}
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed, nil
+}
@@ -0,0 +1,53 @@
+package rss
+
+import (
+	"encoding/xml"
+	"html"
+)
+
+type rssDocument struct {
+	Channel struct {
+		Title       string       `xml:"title"`
+		Link        string       `xml:"link"`
+		Description string       `xml:"description"`
+		Item        []rssDocItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssDocItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	GUID        string `xml:"guid"`
+	Author      string `xml:"author"`
+	PubDate     string `xml:"pubDate"`
+}
+
+func parseRSS(body []byte) (*Feed, error) {
+	var doc rssDocument
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+
+	feed := &Feed{
+		Title:       html.UnescapeString(doc.Channel.Title),
+		Link:        doc.Channel.Link,
+		Description: html.UnescapeString(doc.Channel.Description),
+	}
+
+	for _, docItem := range doc.Channel.Item {
+		item := Item{
+			Title:       html.UnescapeString(docItem.Title),
+			Link:        docItem.Link,
+			Description: html.UnescapeString(docItem.Description),
+			GUID:        docItem.GUID,
+			Published:   parseTime(docItem.PubDate),
+		}
+		if docItem.Author != "" {
+			item.Authors = []string{docItem.Author}
+		}
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed, nil
+}
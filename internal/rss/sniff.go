@@ -0,0 +1,52 @@
+package rss
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// parse content-sniffs body (using contentType plus its root element) and
+// dispatches to the matching format parser.
+func parse(contentType string, body []byte) (*Feed, error) {
+	if looksLikeJSONFeed(contentType, body) {
+		return parseJSONFeed(body)
+	}
+
+	root, err := rootElement(body)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine feed format: %v", err)
+	}
+
+	switch root {
+	case "rss":
+		return parseRSS(body)
+	case "feed":
+		return parseAtom(body)
+	default:
+		return nil, fmt.Errorf("unsupported feed format: root element %q", root)
+	}
+}
+
+func looksLikeJSONFeed(contentType string, body []byte) bool {
+	if strings.Contains(contentType, "json") {
+		return true
+	}
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) > 0 && trimmed[0] == '{' && json.Valid(trimmed)
+}
+
+func rootElement(body []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
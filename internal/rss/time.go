@@ -0,0 +1,21 @@
+package rss
+
+import "time"
+
+var dateLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC822,
+}
+
+// parseTime tries each known feed date layout in turn, returning the zero
+// time.Time if value matches none of them.
+func parseTime(value string) time.Time {
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
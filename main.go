@@ -4,18 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"encoding/xml"
+	"errors"
 	"fmt"
-	"html"
-	"io"
 	"log"
-	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/gemaskus/GatorAggregator/internal/config"
 	"github.com/gemaskus/GatorAggregator/internal/database"
+	"github.com/gemaskus/GatorAggregator/internal/rss"
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type state struct {
@@ -32,20 +32,27 @@ type commands struct {
 	handlers map[string]func(*state, command) error
 }
 
-type RSSFeed struct {
-	Channel struct {
-		Title       string    `xml:"title"`
-		Link        string    `xml:"link"`
-		Description string    `xml:"description"`
-		Item        []RSSItem `xml:"item"`
-	} `xml:"channel"`
+type opmlDocument struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    opmlHead `xml:"head"`
+	Body    opmlBody `xml:"body"`
 }
 
-type RSSItem struct {
-	Title       string `xml:"title"`
-	Link        string `xml:"link"`
-	Description string `xml:"description"`
-	PubDate     string `xml:"pubDate"`
+type opmlHead struct {
+	Title string `xml:"title"`
+}
+
+type opmlBody struct {
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline,omitempty"`
 }
 
 func main() {
@@ -73,8 +80,14 @@ func main() {
 	cmds.register("reset", handlerReset)
 	cmds.register("users", handlerUsers)
 	cmds.register("agg", handlerAgg)
-	cmds.register("addfeed", handlerAddFeed)
+	cmds.register("addfeed", middlewareLoggedIn(handlerAddFeed))
 	cmds.register("feeds", handlerFeeds)
+	cmds.register("follow", middlewareLoggedIn(handlerFollow))
+	cmds.register("unfollow", middlewareLoggedIn(handlerUnfollow))
+	cmds.register("following", middlewareLoggedIn(handlerFollowing))
+	cmds.register("browse", middlewareLoggedIn(handlerBrowse))
+	cmds.register("import", middlewareLoggedIn(handlerImport))
+	cmds.register("export", middlewareLoggedIn(handlerExport))
 
 	args := os.Args
 
@@ -177,19 +190,94 @@ func handlerUsers(s *state, cmd command) error {
 }
 
 func handlerAgg(s *state, cmd command) error {
+	if len(cmd.args) != 1 {
+		return fmt.Errorf("Agg requires exactly one argument: the time between requests (e.g. 1m)")
+	}
 
-	url := "https://www.wagslane.dev/index.xml"
+	timeBetweenRequests, err := time.ParseDuration(cmd.args[0])
+	if err != nil {
+		return fmt.Errorf("Invalid duration: %v", err)
+	}
+
+	fmt.Printf("Collecting feeds every %s\n", timeBetweenRequests)
+
+	ticker := time.NewTicker(timeBetweenRequests)
+	for ; ; <-ticker.C {
+		scrapeFeeds(s)
+	}
+}
 
-	RSSfeed, err := fetchFeed(context.Background(), url)
+func scrapeFeeds(s *state) {
+	feed, err := s.db.GetNextFeedToFetch(context.Background())
 	if err != nil {
-		return err
+		log.Printf("Could not get next feed to fetch: %v", err)
+		return
 	}
 
-	fmt.Printf("%v", RSSfeed)
+	feed, err = s.db.MarkFeedFetched(context.Background(), feed.ID)
+	if err != nil {
+		log.Printf("Could not mark feed %s fetched: %v", feed.Name, err)
+		return
+	}
+
+	parsedFeed, err := rss.Fetch(context.Background(), feed.Url)
+	if err != nil {
+		log.Printf("Could not fetch feed %s: %v", feed.Name, err)
+		return
+	}
+
+	for _, item := range parsedFeed.Items {
+		newPostParams := database.CreatePostParams{
+			ID:          uuid.New(),
+			CreatedAt:   time.Now().Local(),
+			UpdatedAt:   time.Now().Local(),
+			Title:       item.Title,
+			Url:         item.Link,
+			Description: sql.NullString{String: item.Description, Valid: item.Description != ""},
+			PublishedAt: sql.NullTime{Time: item.Published, Valid: !item.Published.IsZero()},
+			FeedID:      feed.ID,
+		}
+
+		_, err := s.db.CreatePost(context.Background(), newPostParams)
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+				continue
+			}
+			log.Printf("Could not save post %q: %v", item.Title, err)
+		}
+	}
+}
+
+func handlerBrowse(s *state, cmd command, user database.User) error {
+	if len(cmd.args) > 1 {
+		return fmt.Errorf("Too many arguments for the browse command")
+	}
+
+	limit := 2
+	if len(cmd.args) == 1 {
+		parsedLimit, err := strconv.Atoi(cmd.args[0])
+		if err != nil {
+			return fmt.Errorf("Invalid limit: %v", err)
+		}
+		limit = parsedLimit
+	}
+
+	posts, err := s.db.GetPostsForUser(context.Background(), database.GetPostsForUserParams{
+		UserID: user.ID,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return fmt.Errorf("Could not retrieve posts: %v", err)
+	}
+
+	for _, post := range posts {
+		fmt.Printf("%s\n%s\n\n", post.Title, post.Url)
+	}
 	return nil
 }
 
-func handlerAddFeed(s *state, cmd command) error {
+func handlerAddFeed(s *state, cmd command, user database.User) error {
 	if len(cmd.args) < 2 {
 		return fmt.Errorf("Too few arguments for adding a feed")
 	}
@@ -197,10 +285,6 @@ func handlerAddFeed(s *state, cmd command) error {
 	if len(cmd.args) > 2 {
 		return fmt.Errorf("Too many arguments for adding a feed")
 	}
-	currentUser, err := s.db.GetUser(context.Background(), s.currentConfig.CurrentUserName)
-	if err != nil {
-		return fmt.Errorf("This really shouldn't happen, unless you try to add a fed without having any users before hand")
-	}
 
 	newFeedParams := database.CreateFeedParams{
 		ID:        uuid.New(),
@@ -208,7 +292,7 @@ func handlerAddFeed(s *state, cmd command) error {
 		UpdatedAt: time.Now().Local(),
 		Name:      cmd.args[0],
 		Url:       cmd.args[1],
-		UserID:    currentUser.ID,
+		UserID:    user.ID,
 	}
 
 	newFeed, err := s.db.CreateFeed(context.Background(), newFeedParams)
@@ -216,11 +300,240 @@ func handlerAddFeed(s *state, cmd command) error {
 		return err
 	}
 
+	newFollowParams := database.CreateFeedFollowParams{
+		ID:        uuid.New(),
+		CreatedAt: time.Now().Local(),
+		UpdatedAt: time.Now().Local(),
+		UserID:    user.ID,
+		FeedID:    newFeed.ID,
+	}
+
+	if _, err := s.db.CreateFeedFollow(context.Background(), newFollowParams); err != nil {
+		return fmt.Errorf("Could not follow newly created feed: %v", err)
+	}
+
 	fmt.Printf("New Feed Created: %v\n", newFeed)
 
 	return nil
 }
 
+func handlerFollow(s *state, cmd command, user database.User) error {
+	if len(cmd.args) != 1 {
+		return fmt.Errorf("Follow requires exactly one argument: the feed URL")
+	}
+
+	feed, err := s.db.GetFeedByURL(context.Background(), cmd.args[0])
+	if err != nil {
+		return fmt.Errorf("Could not find a feed with that URL: %v", err)
+	}
+
+	newFollowParams := database.CreateFeedFollowParams{
+		ID:        uuid.New(),
+		CreatedAt: time.Now().Local(),
+		UpdatedAt: time.Now().Local(),
+		UserID:    user.ID,
+		FeedID:    feed.ID,
+	}
+
+	newFollow, err := s.db.CreateFeedFollow(context.Background(), newFollowParams)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s is now following %s\n", newFollow.UserName, newFollow.FeedName)
+	return nil
+}
+
+func handlerUnfollow(s *state, cmd command, user database.User) error {
+	if len(cmd.args) != 1 {
+		return fmt.Errorf("Unfollow requires exactly one argument: the feed URL")
+	}
+
+	unfollowParams := database.DeleteFeedFollowParams{
+		UserID: user.ID,
+		Url:    cmd.args[0],
+	}
+
+	if err := s.db.DeleteFeedFollow(context.Background(), unfollowParams); err != nil {
+		return fmt.Errorf("Could not unfollow feed: %v", err)
+	}
+
+	return nil
+}
+
+func handlerFollowing(s *state, cmd command, user database.User) error {
+	if len(cmd.args) != 0 {
+		return fmt.Errorf("too many arguments for the following command")
+	}
+
+	follows, err := s.db.GetFeedFollowsForUser(context.Background(), user.ID)
+	if err != nil {
+		return fmt.Errorf("Could not retrieve followed feeds: %v", err)
+	}
+
+	for _, follow := range follows {
+		fmt.Printf("* %s\n", follow.FeedName)
+	}
+	return nil
+}
+
+func handlerImport(s *state, cmd command, user database.User) error {
+	if len(cmd.args) != 1 {
+		return fmt.Errorf("Import requires exactly one argument: the OPML file path")
+	}
+
+	data, err := os.ReadFile(cmd.args[0])
+	if err != nil {
+		return fmt.Errorf("Could not read OPML file: %v", err)
+	}
+
+	var doc opmlDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("Could not parse OPML file: %v", err)
+	}
+
+	for _, outline := range doc.Body.Outlines {
+		if err := importOutline(s, user, outline, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func importOutline(s *state, user database.User, outline opmlOutline, category string) error {
+	if outline.XMLURL == "" {
+		groupCategory := outline.Text
+		if outline.Title != "" {
+			groupCategory = outline.Title
+		}
+		for _, child := range outline.Outlines {
+			if err := importOutline(s, user, child, groupCategory); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	name := outline.Title
+	if name == "" {
+		name = outline.Text
+	}
+
+	feed, err := s.db.GetFeedByURL(context.Background(), outline.XMLURL)
+	if err != nil {
+		newFeedParams := database.CreateFeedParams{
+			ID:        uuid.New(),
+			CreatedAt: time.Now().Local(),
+			UpdatedAt: time.Now().Local(),
+			Name:      name,
+			Url:       outline.XMLURL,
+			UserID:    user.ID,
+		}
+		feed, err = s.db.CreateFeed(context.Background(), newFeedParams)
+		if err != nil {
+			return fmt.Errorf("Could not create feed %q: %v", name, err)
+		}
+	}
+
+	if category != "" {
+		if _, err := s.db.SetFeedCategory(context.Background(), database.SetFeedCategoryParams{
+			ID:       feed.ID,
+			Category: sql.NullString{String: category, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("Could not set category for feed %q: %v", name, err)
+		}
+	}
+
+	newFollowParams := database.CreateFeedFollowParams{
+		ID:        uuid.New(),
+		CreatedAt: time.Now().Local(),
+		UpdatedAt: time.Now().Local(),
+		UserID:    user.ID,
+		FeedID:    feed.ID,
+	}
+	if _, err := s.db.CreateFeedFollow(context.Background(), newFollowParams); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+			return nil
+		}
+		return fmt.Errorf("Could not follow feed %q: %v", name, err)
+	}
+
+	fmt.Printf("Imported feed: %s\n", name)
+	return nil
+}
+
+func handlerExport(s *state, cmd command, user database.User) error {
+	if len(cmd.args) > 1 {
+		return fmt.Errorf("Too many arguments for the export command")
+	}
+
+	path := "gator_feeds.opml"
+	if len(cmd.args) == 1 {
+		path = cmd.args[0]
+	}
+
+	feeds, err := s.db.GetFeedsFollowedByUser(context.Background(), user.ID)
+	if err != nil {
+		return fmt.Errorf("Could not retrieve followed feeds: %v", err)
+	}
+
+	grouped := make(map[string][]database.Feed)
+	var categories []string
+	for _, feed := range feeds {
+		category := "Uncategorized"
+		if feed.Category.Valid && feed.Category.String != "" {
+			category = feed.Category.String
+		}
+		if _, exists := grouped[category]; !exists {
+			categories = append(categories, category)
+		}
+		grouped[category] = append(grouped[category], feed)
+	}
+
+	doc := opmlDocument{
+		Version: "2.0",
+		Head:    opmlHead{Title: "Gator Feeds"},
+	}
+	for _, category := range categories {
+		var outlines []opmlOutline
+		for _, feed := range grouped[category] {
+			outlines = append(outlines, opmlOutline{
+				Text:   feed.Name,
+				Title:  feed.Name,
+				Type:   "rss",
+				XMLURL: feed.Url,
+			})
+		}
+		doc.Body.Outlines = append(doc.Body.Outlines, opmlOutline{
+			Text:     category,
+			Title:    category,
+			Outlines: outlines,
+		})
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Could not generate OPML: %v", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("Could not create export file: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(xml.Header); err != nil {
+		return err
+	}
+	if _, err := file.Write(out); err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d feeds to %s\n", len(feeds), path)
+	return nil
+}
+
 func handlerFeeds(s *state, cmd command) error {
 	if len(cmd.args) > 0 {
 		return fmt.Errorf("Too many arguments for listing active feeds")
@@ -248,6 +561,16 @@ func (cmds *commands) register(name string, f func(*state, command) error) {
 	cmds.handlers[name] = f
 }
 
+func middlewareLoggedIn(handler func(s *state, cmd command, user database.User) error) func(*state, command) error {
+	return func(s *state, cmd command) error {
+		user, err := s.db.GetUser(context.Background(), s.currentConfig.CurrentUserName)
+		if err != nil {
+			return fmt.Errorf("You must be logged in to run this command: %v", err)
+		}
+		return handler(s, cmd, user)
+	}
+}
+
 func (cmds *commands) run(s *state, cmd command) error {
 	if handler, exists := cmds.handlers[cmd.name]; exists {
 		return handler(s, cmd)
@@ -255,36 +578,3 @@ func (cmds *commands) run(s *state, cmd command) error {
 	return fmt.Errorf("Command not found: %s", cmd.name)
 }
 
-func fetchFeed(ctx context.Context, feedURL string) (*RSSFeed, error) {
-	request, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
-	if err != nil {
-		return &RSSFeed{}, err
-	}
-	request.Header.Add("User-Agent", "gator")
-
-	resp, err := http.DefaultClient.Do(request)
-	if err != nil {
-		return &RSSFeed{}, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return &RSSFeed{}, err
-	}
-	newRSSFeed := RSSFeed{}
-	err = xml.Unmarshal(body, &newRSSFeed)
-	if err != nil {
-		return &RSSFeed{}, nil
-	}
-
-	newRSSFeed.Channel.Title = html.UnescapeString(newRSSFeed.Channel.Title)
-	newRSSFeed.Channel.Description = html.UnescapeString(newRSSFeed.Channel.Description)
-	for _, item := range newRSSFeed.Channel.Item {
-		item.Title = html.UnescapeString(item.Title)
-		item.Description = html.UnescapeString(item.Description)
-	}
-
-	return &newRSSFeed, nil
-
-}